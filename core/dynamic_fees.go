@@ -0,0 +1,59 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package core
+
+import (
+	"math/big"
+
+	"github.com/ActaFi/subnet-evm/precompile"
+)
+
+// GetEffectiveFeeConfig returns the dynamic fee configuration in effect for the block being built
+// on top of [state]: the fee config manager precompile's on-chain value, seeded from the genesis
+// default at genesis and mutable afterward by any FeeConfigManager admin via setFeeConfig. This is
+// what block processing consults instead of the chain's static genesis fee config, so an on-chain
+// update takes effect at the very next block.
+func GetEffectiveFeeConfig(state precompile.StateDB) *precompile.FeeConfig {
+	return precompile.GetFeeConfig(state)
+}
+
+// CalcBaseFee computes the base fee of the block following [parentBaseFee], given [parentGasUsed]
+// and [feeConfig] (the value GetEffectiveFeeConfig read from on-chain state for that block). It
+// mirrors EIP-1559's base fee adjustment, except the target gas per block and the adjustment
+// denominator are the chain's configurable TargetGas/BaseFeeChangeDenominator instead of fixed
+// protocol constants, so admins can retune them without a hard fork. Unlike EIP-1559, a decrease
+// also moves the base fee by at least 1, and the result never falls below MinBaseFee.
+func CalcBaseFee(feeConfig *precompile.FeeConfig, parentBaseFee *big.Int, parentGasUsed uint64) *big.Int {
+	targetGas := feeConfig.TargetGas.Uint64()
+
+	if parentGasUsed == targetGas {
+		return new(big.Int).Set(parentBaseFee)
+	}
+
+	if parentGasUsed > targetGas {
+		gasUsedDelta := parentGasUsed - targetGas
+		baseFeeDelta := baseFeeChangeDelta(feeConfig, parentBaseFee, gasUsedDelta, targetGas)
+		return new(big.Int).Add(parentBaseFee, baseFeeDelta)
+	}
+
+	gasUsedDelta := targetGas - parentGasUsed
+	baseFeeDelta := baseFeeChangeDelta(feeConfig, parentBaseFee, gasUsedDelta, targetGas)
+	baseFee := new(big.Int).Sub(parentBaseFee, baseFeeDelta)
+	if baseFee.Cmp(feeConfig.MinBaseFee) < 0 {
+		return new(big.Int).Set(feeConfig.MinBaseFee)
+	}
+	return baseFee
+}
+
+// baseFeeChangeDelta computes how much the base fee moves for a [gasUsedDelta] away from
+// [targetGas], scaled by [parentBaseFee] and the chain's BaseFeeChangeDenominator.
+func baseFeeChangeDelta(feeConfig *precompile.FeeConfig, parentBaseFee *big.Int, gasUsedDelta, targetGas uint64) *big.Int {
+	delta := new(big.Int).Mul(parentBaseFee, new(big.Int).SetUint64(gasUsedDelta))
+	delta.Div(delta, new(big.Int).SetUint64(targetGas))
+	delta.Div(delta, feeConfig.BaseFeeChangeDenominator)
+	if delta.Sign() == 0 {
+		delta.SetUint64(1)
+	}
+	return delta
+}
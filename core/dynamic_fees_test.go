@@ -0,0 +1,91 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package core
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ActaFi/subnet-evm/precompile"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// mockAccessibleState is a minimal precompile.PrecompileAccessibleState backed by a mockStateDB, so
+// this package can drive FeeConfigManagerPrecompile.Run directly instead of reaching into
+// unexported precompile package state.
+type mockAccessibleState struct {
+	state *mockStateDB
+}
+
+func (m *mockAccessibleState) GetStateDB() precompile.StateDB { return m.state }
+
+func (m *mockAccessibleState) GetBlockNumber() *big.Int { return common.Big0 }
+
+func testFeeConfig() *precompile.FeeConfig {
+	return &precompile.FeeConfig{
+		GasLimit:                 big.NewInt(8_000_000),
+		TargetBlockRate:          big.NewInt(2),
+		MinBaseFee:               big.NewInt(25_000_000_000),
+		TargetGas:                big.NewInt(15_000_000),
+		BaseFeeChangeDenominator: big.NewInt(36),
+		MinBlockGasCost:          big.NewInt(0),
+		MaxBlockGasCost:          big.NewInt(1_000_000),
+		BlockGasCostStep:         big.NewInt(200_000),
+	}
+}
+
+func TestCalcBaseFee(t *testing.T) {
+	feeConfig := testFeeConfig()
+	parentBaseFee := big.NewInt(100_000_000_000)
+
+	if got := CalcBaseFee(feeConfig, parentBaseFee, feeConfig.TargetGas.Uint64()); got.Cmp(parentBaseFee) != 0 {
+		t.Fatalf("expected base fee to stay at %s when gas used matches target, got %s", parentBaseFee, got)
+	}
+
+	above := CalcBaseFee(feeConfig, parentBaseFee, feeConfig.TargetGas.Uint64()*2)
+	if above.Cmp(parentBaseFee) <= 0 {
+		t.Fatalf("expected base fee to rise above %s when gas used exceeds target, got %s", parentBaseFee, above)
+	}
+
+	below := CalcBaseFee(feeConfig, parentBaseFee, 0)
+	if below.Cmp(parentBaseFee) >= 0 {
+		t.Fatalf("expected base fee to fall below %s when no gas was used, got %s", parentBaseFee, below)
+	}
+
+	floored := CalcBaseFee(feeConfig, new(big.Int).Set(feeConfig.MinBaseFee), 0)
+	if floored.Cmp(feeConfig.MinBaseFee) != 0 {
+		t.Fatalf("expected base fee to be floored at MinBaseFee %s, got %s", feeConfig.MinBaseFee, floored)
+	}
+}
+
+func TestGetEffectiveFeeConfigReflectsOnChainUpdate(t *testing.T) {
+	state := newMockStateDB()
+	genesisFeeConfig := testFeeConfig()
+	(&precompile.FeeConfigManagerConfig{InitialFeeConfig: genesisFeeConfig}).Configure(state)
+
+	if got := GetEffectiveFeeConfig(state); got.MinBaseFee.Cmp(genesisFeeConfig.MinBaseFee) != 0 {
+		t.Fatalf("expected the genesis fee config, got %+v", got)
+	}
+
+	updated := testFeeConfig()
+	updated.MinBaseFee = big.NewInt(50_000_000_000)
+	setFeeConfigInput, err := precompile.PackSetFeeConfig(updated)
+	if err != nil {
+		t.Fatalf("failed to pack updated fee config: %s", err)
+	}
+
+	admin := common.HexToAddress("0x1")
+	adminConfig := &precompile.FeeConfigManagerConfig{AllowListConfig: precompile.AllowListConfig{AllowListAdmins: []common.Address{admin}}}
+	adminConfig.Configure(state)
+
+	evm := &mockAccessibleState{state: state}
+	contract := precompile.FeeConfigManagerPrecompile
+	if _, _, err := contract.Run(evm, admin, precompile.FeeConfigManagerAddress, setFeeConfigInput, contract.RequiredGas(setFeeConfigInput), false); err != nil {
+		t.Fatalf("failed to update fee config: %s", err)
+	}
+
+	if got := GetEffectiveFeeConfig(state); got.MinBaseFee.Cmp(updated.MinBaseFee) != 0 {
+		t.Fatalf("expected the on-chain update to override the genesis default, got %+v", got)
+	}
+}
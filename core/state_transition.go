@@ -0,0 +1,68 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package core
+
+import (
+	"fmt"
+
+	"github.com/ActaFi/subnet-evm/precompile"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Message represents the subset of a transaction needed by StateTransition.preCheck: who is
+// submitting it, its nonce, and whether the nonce should be checked at all (disabled for calls
+// made outside of transaction processing, e.g. eth_call).
+type Message interface {
+	From() common.Address
+	Nonce() uint64
+	CheckNonce() bool
+}
+
+// StateTransition drives the pre-state-transition validation of a message against [state] before
+// any state transition is applied, so that an invalid message is rejected before gas is spent.
+type StateTransition struct {
+	state precompile.StateDB
+	msg   Message
+}
+
+// NewStateTransition returns a new StateTransition for applying [msg] against [state].
+func NewStateTransition(state precompile.StateDB, msg Message) *StateTransition {
+	return &StateTransition{state: state, msg: msg}
+}
+
+// ApplyMessage validates [msg] against [state] by running TransitionDb. It is the entry
+// point the block processor calls for every transaction in a block, and that transaction
+// submission calls before accepting a transaction into the pool, so this is where a message from a
+// sender the tx allow list rejects gets turned away before any state transition is applied.
+func ApplyMessage(state precompile.StateDB, msg Message) error {
+	return NewStateTransition(state, msg).TransitionDb()
+}
+
+// TransitionDb runs preCheck and returns its result. It does not execute the message: there is no
+// nonce bump, gas deduction or EVM call, and [state] is left unchanged.
+func (st *StateTransition) TransitionDb() error {
+	return st.preCheck()
+}
+
+// preCheck verifies that the message is valid before any state transition is applied: the nonce
+// must match, and, if the tx allow list is enabled, the sender must be allowed to originate a
+// transaction.
+func (st *StateTransition) preCheck() error {
+	if st.msg.CheckNonce() {
+		stNonce := st.state.GetNonce(st.msg.From())
+		if msgNonce := st.msg.Nonce(); stNonce < msgNonce {
+			return fmt.Errorf("nonce too high: address %s, tx: %d state: %d", st.msg.From(), msgNonce, stNonce)
+		} else if stNonce > msgNonce {
+			return fmt.Errorf("nonce too low: address %s, tx: %d state: %d", st.msg.From(), msgNonce, stNonce)
+		}
+	}
+
+	// Reject the message before any state transition is applied if the sender is not allowed to
+	// originate a transaction under the configured tx allow list.
+	if err := CheckTxAllowList(st.state, st.msg.From()); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,76 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package core
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ActaFi/subnet-evm/precompile"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// mockStateDB is a minimal in-memory precompile.StateDB, storing one state slot per (address, key)
+// plus a nonce per address.
+type mockStateDB struct {
+	state  map[common.Address]map[common.Hash]common.Hash
+	nonces map[common.Address]uint64
+}
+
+func newMockStateDB() *mockStateDB {
+	return &mockStateDB{
+		state:  make(map[common.Address]map[common.Hash]common.Hash),
+		nonces: make(map[common.Address]uint64),
+	}
+}
+
+func (m *mockStateDB) GetState(addr common.Address, key common.Hash) common.Hash {
+	return m.state[addr][key]
+}
+
+func (m *mockStateDB) SetState(addr common.Address, key common.Hash, value common.Hash) {
+	if m.state[addr] == nil {
+		m.state[addr] = make(map[common.Hash]common.Hash)
+	}
+	m.state[addr][key] = value
+}
+
+func (m *mockStateDB) GetNonce(addr common.Address) uint64 { return m.nonces[addr] }
+
+func (m *mockStateDB) AddBalance(addr common.Address, amount *big.Int) {}
+
+func (m *mockStateDB) AddLog(log *types.Log) {}
+
+// mockMessage is a minimal Message for testing StateTransition.preCheck.
+type mockMessage struct {
+	from       common.Address
+	nonce      uint64
+	checkNonce bool
+}
+
+func (m *mockMessage) From() common.Address { return m.from }
+func (m *mockMessage) Nonce() uint64        { return m.nonce }
+func (m *mockMessage) CheckNonce() bool     { return m.checkNonce }
+
+func TestApplyMessageRejectsDisallowedSender(t *testing.T) {
+	allowed := common.HexToAddress("0x1")
+	disallowed := common.HexToAddress("0x2")
+
+	state := newMockStateDB()
+	state.SetState(precompile.TxAllowListAddress, precompile.CreateAddressKey(allowed), common.Hash(precompile.Submitter))
+
+	err := ApplyMessage(state, &mockMessage{from: disallowed})
+	if err == nil {
+		t.Fatal("expected a disallowed sender to be rejected")
+	}
+	if !errors.Is(err, ErrSenderAddressNotAllowListed) {
+		t.Fatalf("expected ErrSenderAddressNotAllowListed, got: %s", err)
+	}
+
+	if err := ApplyMessage(state, &mockMessage{from: allowed}); err != nil {
+		t.Fatalf("expected an allowed sender to pass preCheck, got: %s", err)
+	}
+}
@@ -0,0 +1,26 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package core
+
+import (
+	"fmt"
+
+	"github.com/ActaFi/subnet-evm/precompile"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// ErrSenderAddressNotAllowListed is returned from the pre-state-transition checks in
+// StateTransition.preCheck when the tx allow list is enabled and the transaction's sender is not
+// permitted to submit transactions.
+var ErrSenderAddressNotAllowListed = fmt.Errorf("tx sender is not on the tx allow list")
+
+// CheckTxAllowList verifies that [from] is allowed to originate a transaction under the tx allow
+// list configured in [state]. It is called from StateTransition.preCheck, before any state
+// transition is applied, so that a disallowed sender is rejected before gas is spent.
+func CheckTxAllowList(state precompile.StateDB, from common.Address) error {
+	if !precompile.IsTxAllowed(state, from) {
+		return fmt.Errorf("%w: %s", ErrSenderAddressNotAllowListed, from)
+	}
+	return nil
+}
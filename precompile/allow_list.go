@@ -8,30 +8,150 @@ import (
 	"math/big"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/log"
 )
 
-// Singleton StatefulPrecompiledContracts for W/R access to the contract deployer allow list.
-var (
-	ModifyAllowListPrecompile StatefulPrecompiledContract = &modifyAllowListPrecompile{}
-	ReadAllowListPrecompile   StatefulPrecompiledContract = &readAllowListPrecompile{}
+// RoleSetEventTopic is the topic hash of RoleSet(address,address,bytes32,bytes32), emitted by every
+// allow-list-gated precompile whenever a role is changed, so off-chain indexers and Solidity event
+// ABIs can subscribe to role changes without polling state.
+var RoleSetEventTopic = common.HexToHash("0xab08fef83b853b2231069e82f71a1fa720c603d2f0c67b9778dd9e76afb9fdb6")
+
+const (
+	// roleSetLogTopics is the number of topics in a RoleSet log: the event signature plus the
+	// indexed target address and caller address.
+	roleSetLogTopics = 3
+	// roleSetLogDataLen is the length of a RoleSet log's data: the old and new role, each a 32 byte word.
+	roleSetLogDataLen = 2 * common.HashLength
+
+	// logGasCostPerTopic and logGasCostPerByte mirror the EVM's LOG opcode pricing, so emitting
+	// this log from a precompile remains as expensive as emitting it from a contract would be.
+	logGasCostPerTopic uint64 = 375
+	logGasCostPerByte  uint64 = 8
+	logGasCostBase     uint64 = 375
 )
 
+// roleSetLogGasCost returns the additional gas charged for emitting a RoleSet log, on top of the
+// base cost of modifying the allow list.
+func roleSetLogGasCost() uint64 {
+	return logGasCostBase + logGasCostPerTopic*roleSetLogTopics + logGasCostPerByte*roleSetLogDataLen
+}
+
+// emitRoleSetLog appends a RoleSet log to [evm]'s state, recording that [caller] changed the role of
+// [target] on the allow list at [precompileAddr] from [oldRole] to [newRole].
+func emitRoleSetLog(evm PrecompileAccessibleState, precompileAddr common.Address, target common.Address, caller common.Address, oldRole common.Hash, newRole common.Hash) {
+	evm.GetStateDB().AddLog(&types.Log{
+		Address: precompileAddr,
+		Topics: []common.Hash{
+			RoleSetEventTopic,
+			common.BytesToHash(target[:]),
+			common.BytesToHash(caller[:]),
+		},
+		Data:        append(append([]byte{}, oldRole[:]...), newRole[:]...),
+		BlockNumber: evm.GetBlockNumber().Uint64(),
+	})
+}
+
+// DeployerAllowListPrecompile is the single contract installed at [ModifyAllowListAddress]: a
+// registry only has room for one Contract per address, so modify and read both have to be reached
+// through one dispatching contract rather than two independent singletons.
+var DeployerAllowListPrecompile StatefulPrecompiledContract = NewAllowListPrecompile(ModifyAllowListAddress)
+
+// DeployerAllowListConfigKey is the JSON key the contract deployer allow list's genesis/upgrade
+// config is nested under.
+const DeployerAllowListConfigKey = "contractDeployerAllowListConfig"
+
+// DeployerAllowListConfig specifies the configuration of the contract deployer allow list, using the
+// AllowListConfig shape shared by every allow-list-gated precompile module.
+type DeployerAllowListConfig struct {
+	AllowListConfig
+}
+
+// Configure initializes the contract deployer allow list's address space by granting Admin to each
+// address in [AllowListAdmins].
+func (c *DeployerAllowListConfig) Configure(state StateDB) {
+	c.AllowListConfig.Configure(state, ModifyAllowListAddress)
+}
+
+func init() {
+	RegisterModule(StatefulPrecompileModule{
+		ConfigKey: DeployerAllowListConfigKey,
+		Address:   ModifyAllowListAddress,
+		NewConfig: func() StatefulPrecompileConfig { return new(DeployerAllowListConfig) },
+		Contract:  DeployerAllowListPrecompile,
+	})
+}
+
+// allowListPrecompile implements StatefulPrecompiledContract and can be used as a thread-safe
+// singleton. It dispatches a raw or ABI call to modify, read, or read-only logic by input length, so
+// that a single installable contract can serve all three views of one allow list at one address,
+// instead of leaving some of them unreachable once only one Contract can be registered per address.
+type allowListPrecompile struct {
+	modify   StatefulPrecompiledContract
+	read     StatefulPrecompiledContract
+	readOnly StatefulPrecompiledContract
+}
+
+// NewAllowListPrecompile returns a StatefulPrecompiledContract that serves modify, read, and
+// ABI-style read-only access to the allow list at [precompileAddr] from a single installable
+// contract.
+func NewAllowListPrecompile(precompileAddr common.Address) StatefulPrecompiledContract {
+	return &allowListPrecompile{
+		modify:   NewModifyAllowListPrecompile(precompileAddr),
+		read:     NewReadAllowListPrecompile(precompileAddr),
+		readOnly: NewReadOnlyAllowListPrecompile(precompileAddr),
+	}
+}
+
+// Run dispatches [input] to the modify, read, or read-only precompile it was built for, chosen by
+// the length of [input]: a fixed-length address+role word for modify, a bare address for read, a
+// selector+address word for the ABI-style read-only queries. None of the three collide.
+func (al *allowListPrecompile) Run(evm PrecompileAccessibleState, callerAddr common.Address, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+	switch len(input) {
+	case modifyAllowListInputLength:
+		return al.modify.Run(evm, callerAddr, addr, input, suppliedGas, readOnly)
+	case common.AddressLength:
+		return al.read.Run(evm, callerAddr, addr, input, suppliedGas, readOnly)
+	case readOnlyAllowListInputLength:
+		return al.readOnly.Run(evm, callerAddr, addr, input, suppliedGas, readOnly)
+	default:
+		return nil, suppliedGas, fmt.Errorf("invalid input length for allow list: %d", len(input))
+	}
+}
+
+// RequiredGas returns the amount of gas consumed by this precompile for [input], matching whichever
+// of modify/read/readOnly Run would dispatch to.
+func (al *allowListPrecompile) RequiredGas(input []byte) uint64 {
+	switch len(input) {
+	case modifyAllowListInputLength:
+		return al.modify.RequiredGas(input)
+	case readOnlyAllowListInputLength:
+		return al.readOnly.RequiredGas(input)
+	default:
+		return al.read.RequiredGas(input)
+	}
+}
+
 type AllowListRole common.Hash
 
 // Enum constants for valid AllowListRole
 var (
-	None     AllowListRole = AllowListRole(common.Hash{})  // No role assigned - this is equivalent to common.Hash{} and deletes the key from the DB when set
-	Deployer AllowListRole = AllowListRole(common.Hash{1}) // Deployers are allowed to create new contracts
-	Admin    AllowListRole = AllowListRole(common.Hash{2}) // Admin - allowed to modify both the admin and deployer list as well as deploy contracts
+	None      AllowListRole = AllowListRole(common.Hash{})  // No role assigned - this is equivalent to common.Hash{} and deletes the key from the DB when set
+	Deployer  AllowListRole = AllowListRole(common.Hash{1}) // Deployers are allowed to create new contracts
+	Admin     AllowListRole = AllowListRole(common.Hash{2}) // Admin - allowed to modify both the admin and deployer list as well as deploy contracts
+	Manager   AllowListRole = AllowListRole(common.Hash{3}) // Manager - allowed to add/remove Deployer entries, but cannot promote admins or managers
+	Minter    AllowListRole = AllowListRole(common.Hash{4}) // Minter - allowed to mint native coin on the native minter precompile
+	Submitter AllowListRole = AllowListRole(common.Hash{5}) // Submitter - allowed to originate ("from") a transaction on the tx allow list
 )
 
 const (
 	modifyAllowListInputLength = common.AddressLength + common.HashLength // Required length of an input to modify allow list precompile
 )
 
-// AllowListConfig specifies the configuration of the allow list.
+// AllowListConfig specifies the configuration of an allow list.
 // Specifies the block timestamp at which it goes into effect as well as the initial set of allow list admins.
+// It is shared by every allow-list-gated precompile (the contract deployer allow list, the tx allow list, etc.),
+// each of which supplies its own precompile address when calling Configure.
 type AllowListConfig struct {
 	BlockTimestamp *big.Int `json:"blockTimestamp"`
 
@@ -41,18 +161,18 @@ type AllowListConfig struct {
 // Timestamp returns the timestamp at which the allow list should be enabled
 func (c *AllowListConfig) Timestamp() *big.Int { return c.BlockTimestamp }
 
-// Configure initializes the address space of [ModifyAllowListAddress] by initializing the role of each of
+// Configure initializes the address space of [precompileAddr] by initializing the role of each of
 // the addresses in [AllowListAdmins].
-func (c *AllowListConfig) Configure(state StateDB) {
+func (c *AllowListConfig) Configure(state StateDB, precompileAddr common.Address) {
 	for _, adminAddr := range c.AllowListAdmins {
-		state.SetState(ModifyAllowListAddress, CreateAddressKey(adminAddr), common.Hash(Admin))
+		setAllowListStatus(state, precompileAddr, adminAddr, common.Hash(Admin))
 	}
 }
 
 // Valid returns true iff [s] represents a valid role.
 func (s AllowListRole) Valid() bool {
 	switch s {
-	case None, Deployer, Admin:
+	case None, Deployer, Admin, Manager, Minter, Submitter:
 		return true
 	default:
 		return false
@@ -69,6 +189,17 @@ func (s AllowListRole) IsAdmin() bool {
 	}
 }
 
+// CanManage returns true if [s] indicates the permission to modify the allow list: true for Admin,
+// and for Manager, which may add/remove Deployer entries but cannot grant Admin or Manager.
+func (s AllowListRole) CanManage() bool {
+	switch s {
+	case Manager, Admin:
+		return true
+	default:
+		return false
+	}
+}
+
 // HasDeployerPrivileges returns true iff [s] indicates the permission to deploy contracts.
 func (s AllowListRole) CanDeploy() bool {
 	switch s {
@@ -79,10 +210,10 @@ func (s AllowListRole) CanDeploy() bool {
 	}
 }
 
-// GetAllowListStatus returns the allow list role of [address].
-func GetAllowListStatus(state StateDB, address common.Address) AllowListRole {
+// GetAllowListStatus returns the allow list role of [address] on the allow list at [precompileAddr].
+func GetAllowListStatus(state StateDB, precompileAddr common.Address, address common.Address) AllowListRole {
 	stateSlot := CreateAddressKey(address)
-	res := state.GetState(ModifyAllowListAddress, stateSlot)
+	res := state.GetState(precompileAddr, stateSlot)
 	return AllowListRole(res)
 }
 
@@ -118,19 +249,27 @@ func UnpackModifyAllowList(input []byte) (common.Address, common.Hash, error) {
 	return address, statusHash, nil
 }
 
-// setAllowListStatus sets the permissions of [address] to [status]
+// setAllowListStatus sets the permissions of [address] to [status] on the allow list at [precompileAddr]
 // assumes [status] has already been verified as valid.
-func setAllowListStatus(stateDB StateDB, address common.Address, status common.Hash) {
+func setAllowListStatus(stateDB StateDB, precompileAddr common.Address, address common.Address, status common.Hash) {
 	// Generate the state key for [address]
 	addressKey := CreateAddressKey(address)
-	log.Info("modify allow list", "address", address, "role", status)
+	log.Info("modify allow list", "precompileAddr", precompileAddr, "address", address, "role", status)
 	// Assign [role] to the address
-	stateDB.SetState(ModifyAllowListAddress, addressKey, status)
+	stateDB.SetState(precompileAddr, addressKey, status)
 }
 
 // modifyAllowListPrecompile implements StatefulPrecompiledContract and can be used as a thread-safe singleton.
-// Provides designated admins to modify the contract deployers allow list.
-type modifyAllowListPrecompile struct{}
+// Provides designated admins of [address] the ability to modify the allow list at [address].
+type modifyAllowListPrecompile struct {
+	address common.Address
+}
+
+// NewModifyAllowListPrecompile returns a StatefulPrecompiledContract that allows admins of the allow list at
+// [precompileAddr] to modify the role of any address on that same allow list.
+func NewModifyAllowListPrecompile(precompileAddr common.Address) StatefulPrecompiledContract {
+	return &modifyAllowListPrecompile{address: precompileAddr}
+}
 
 // Run verifies that [callerAddr] has the correct permissions to modify the allow list and if so updates the the allow list
 // as requested by the arguments encoded in [input].
@@ -145,9 +284,11 @@ func (mal *modifyAllowListPrecompile) Run(evm PrecompileAccessibleState, callerA
 		return nil, remainingGas, fmt.Errorf("cannot modify allow list in read only")
 	}
 
-	// Verify that the caller is in the allow list and therefore has the right to modify it
-	callerStatus := GetAllowListStatus(evm.GetStateDB(), callerAddr)
-	if !callerStatus.IsAdmin() {
+	// Verify that the caller is in the allow list and therefore has the right to modify it. Admins
+	// may make any change; Managers may assign any role other than Admin or Manager to any address
+	// that is not currently an Admin.
+	callerStatus := GetAllowListStatus(evm.GetStateDB(), mal.address, callerAddr)
+	if !callerStatus.CanManage() {
 		log.Info("EVM received attempt to modify the allow list from a non-allowed address", "callerAddr", callerAddr)
 		return nil, remainingGas, fmt.Errorf("caller %s cannot modify allow list", callerAddr)
 	}
@@ -159,18 +300,50 @@ func (mal *modifyAllowListPrecompile) Run(evm PrecompileAccessibleState, callerA
 		return nil, remainingGas, fmt.Errorf("failed to unpack modify allow list input: %w", err)
 	}
 
-	setAllowListStatus(evm.GetStateDB(), address, status)
+	if !callerStatus.IsAdmin() {
+		// Managers cannot promote any address to Admin or Manager, nor demote an existing Admin.
+		if AllowListRole(status).IsAdmin() || status == common.Hash(Manager) {
+			return nil, remainingGas, fmt.Errorf("caller %s cannot grant admin or manager status", callerAddr)
+		}
+		if GetAllowListStatus(evm.GetStateDB(), mal.address, address).IsAdmin() {
+			return nil, remainingGas, fmt.Errorf("caller %s cannot modify admin status of %s", callerAddr, address)
+		}
+	}
+
+	logGas := roleSetLogGasCost()
+	if remainingGas < logGas {
+		return nil, 0, fmt.Errorf("running allow list exceeds gas allowance (%d) < (%d)", logGas, remainingGas)
+	}
+	remainingGas -= logGas
+
+	oldStatus := common.Hash(GetAllowListStatus(evm.GetStateDB(), mal.address, address))
+	setAllowListStatus(evm.GetStateDB(), mal.address, address, status)
+	emitRoleSetLog(evm, mal.address, address, callerAddr, oldStatus, status)
 
 	// Return an empty output and the remaining gas
 	return []byte{}, remainingGas, nil
 }
 
-// RequiredGas returns the amount of gas consumed by this precompile.
-func (mal *modifyAllowListPrecompile) RequiredGas(input []byte) uint64 { return ModifyAllowListGasCost }
+// RequiredGas returns the minimum amount of gas that must be supplied to call this precompile: the
+// base cost of a successful modification plus the RoleSet log it emits. Run gates on this value
+// before doing any work, so it must cover the most expensive path even though a call that reverts
+// before reaching the log (read-only, unauthorized, malformed input, forbidden promotion) only
+// consumes ModifyAllowListGasCost and refunds the rest.
+func (mal *modifyAllowListPrecompile) RequiredGas(input []byte) uint64 {
+	return ModifyAllowListGasCost + roleSetLogGasCost()
+}
 
 // readAllowListPrecompile implements StatefulPrecompiledContract and can be used as a thread-safe singleton.
-// Provides read access to the contract deployer allow list.
-type readAllowListPrecompile struct{}
+// Provides read access to the allow list at [address].
+type readAllowListPrecompile struct {
+	address common.Address
+}
+
+// NewReadAllowListPrecompile returns a StatefulPrecompiledContract that provides read access to the allow list
+// at [precompileAddr].
+func NewReadAllowListPrecompile(precompileAddr common.Address) StatefulPrecompiledContract {
+	return &readAllowListPrecompile{address: precompileAddr}
+}
 
 // Run implements StatefulPrecompiledContract
 // parses [input] into a single address and returns the 32 byte hash that specifies the designated role of that address.
@@ -187,9 +360,9 @@ func (ral *readAllowListPrecompile) Run(evm PrecompileAccessibleState, callerAdd
 	}
 
 	readAddress := common.BytesToAddress(input)
-	roleBytes := common.Hash(GetAllowListStatus(evm.GetStateDB(), readAddress)).Bytes()
+	roleBytes := common.Hash(GetAllowListStatus(evm.GetStateDB(), ral.address, readAddress)).Bytes()
 	return roleBytes, remainingGas, nil
 }
 
 // RequiredGas returns the amount of gas consumed by this precompile.
-func (mal *readAllowListPrecompile) RequiredGas(input []byte) uint64 { return ReadAllowListGasCost }
\ No newline at end of file
+func (mal *readAllowListPrecompile) RequiredGas(input []byte) uint64 { return ReadAllowListGasCost }
@@ -0,0 +1,158 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package precompile
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// mockStateDB is a minimal in-memory StateDB backing the single state slot per (address, key) pair
+// that the allow list precompiles read and write, the balances they mint into, and the logs they
+// emit.
+type mockStateDB struct {
+	state    map[common.Address]map[common.Hash]common.Hash
+	balances map[common.Address]*big.Int
+	logs     []*types.Log
+}
+
+func newMockStateDB() *mockStateDB {
+	return &mockStateDB{
+		state:    make(map[common.Address]map[common.Hash]common.Hash),
+		balances: make(map[common.Address]*big.Int),
+	}
+}
+
+func (m *mockStateDB) GetState(addr common.Address, key common.Hash) common.Hash {
+	return m.state[addr][key]
+}
+
+func (m *mockStateDB) SetState(addr common.Address, key common.Hash, value common.Hash) {
+	if m.state[addr] == nil {
+		m.state[addr] = make(map[common.Hash]common.Hash)
+	}
+	m.state[addr][key] = value
+}
+
+func (m *mockStateDB) AddBalance(addr common.Address, amount *big.Int) {
+	balance, ok := m.balances[addr]
+	if !ok {
+		balance = new(big.Int)
+	}
+	m.balances[addr] = new(big.Int).Add(balance, amount)
+}
+
+func (m *mockStateDB) GetBalance(addr common.Address) *big.Int {
+	balance, ok := m.balances[addr]
+	if !ok {
+		return new(big.Int)
+	}
+	return balance
+}
+
+func (m *mockStateDB) GetNonce(addr common.Address) uint64 { return 0 }
+
+func (m *mockStateDB) AddLog(log *types.Log) { m.logs = append(m.logs, log) }
+
+// mockAccessibleState is a minimal PrecompileAccessibleState backed by a mockStateDB. blockNumber
+// defaults to common.Big0 when left unset.
+type mockAccessibleState struct {
+	state       *mockStateDB
+	blockNumber *big.Int
+}
+
+func (m *mockAccessibleState) GetStateDB() StateDB { return m.state }
+
+func (m *mockAccessibleState) GetBlockNumber() *big.Int {
+	if m.blockNumber == nil {
+		return common.Big0
+	}
+	return m.blockNumber
+}
+
+func TestPackUnpackModifyAllowList(t *testing.T) {
+	addr := common.HexToAddress("0x0123")
+	roles := []AllowListRole{None, Deployer, Admin, Manager, Minter, Submitter}
+
+	for _, role := range roles {
+		input, err := PackModifyAllowList(addr, role)
+		if err != nil {
+			t.Fatalf("failed to pack role %v: %s", role, err)
+		}
+		gotAddr, gotStatus, err := UnpackModifyAllowList(input)
+		if err != nil {
+			t.Fatalf("failed to unpack role %v: %s", role, err)
+		}
+		if gotAddr != addr {
+			t.Fatalf("expected address %s, got %s", addr, gotAddr)
+		}
+		if AllowListRole(gotStatus) != role {
+			t.Fatalf("expected role %v, got %v", role, AllowListRole(gotStatus))
+		}
+	}
+
+	if _, err := PackModifyAllowList(addr, AllowListRole(common.Hash{9})); err == nil {
+		t.Fatal("expected error packing an invalid role")
+	}
+
+	if _, _, err := UnpackModifyAllowList(make([]byte, modifyAllowListInputLength+1)); err == nil {
+		t.Fatal("expected error unpacking input of the wrong length")
+	}
+}
+
+func TestModifyAllowListManagerRestrictions(t *testing.T) {
+	precompileAddr := TxAllowListAddress
+	caller := common.HexToAddress("0x1")
+	target := common.HexToAddress("0x2")
+
+	tests := []struct {
+		name       string
+		callerRole AllowListRole
+		targetRole AllowListRole // pre-existing role of target, if any
+		newRole    AllowListRole
+		readOnly   bool
+		wantErr    bool
+	}{
+		{"manager can add a deployer", Manager, None, Deployer, false, false},
+		{"manager can remove a deployer", Manager, Deployer, None, false, false},
+		{"manager cannot promote to admin", Manager, None, Admin, false, true},
+		{"manager cannot promote to manager", Manager, None, Manager, false, true},
+		{"manager cannot modify an existing admin", Manager, Admin, Deployer, false, true},
+		{"admin can promote to admin", Admin, None, Admin, false, false},
+		{"non-allow-listed caller cannot modify", None, None, Deployer, false, true},
+		{"read-only calls are rejected", Manager, None, Deployer, true, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			state := newMockStateDB()
+			setAllowListStatus(state, precompileAddr, caller, common.Hash(test.callerRole))
+			setAllowListStatus(state, precompileAddr, target, common.Hash(test.targetRole))
+			evm := &mockAccessibleState{state: state}
+
+			input, err := PackModifyAllowList(target, test.newRole)
+			if err != nil {
+				t.Fatalf("failed to pack input: %s", err)
+			}
+
+			contract := NewModifyAllowListPrecompile(precompileAddr)
+			_, _, err = contract.Run(evm, caller, precompileAddr, input, contract.RequiredGas(input), test.readOnly)
+			if test.wantErr {
+				if err == nil {
+					t.Fatal("expected an error, got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("expected no error, got: %s", err)
+			}
+			if got := GetAllowListStatus(state, precompileAddr, target); got != test.newRole {
+				t.Fatalf("expected role %v, got %v", test.newRole, got)
+			}
+		})
+	}
+}
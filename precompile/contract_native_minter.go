@@ -0,0 +1,160 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package precompile
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// ContractNativeMinterAddress is the address of the native minter precompile, which allows allow-listed
+// addresses to mint the chain's native coin to an arbitrary recipient.
+var ContractNativeMinterAddress = common.HexToAddress("0x0200000000000000000000000000000000000001")
+
+// Singleton StatefulPrecompiledContracts for W/R access to the native minter allow list.
+var (
+	MinterAllowListPrecompile      StatefulPrecompiledContract = NewModifyAllowListPrecompile(ContractNativeMinterAddress)
+	MinterReadAllowListPrecompile  StatefulPrecompiledContract = NewReadAllowListPrecompile(ContractNativeMinterAddress)
+	ContractNativeMinterPrecompile StatefulPrecompiledContract = &contractNativeMinterPrecompile{}
+)
+
+const (
+	selectorLength  = 4                                                      // length of a Solidity ABI function selector
+	mintInputLength = selectorLength + common.HashLength + common.HashLength // selector + address word + amount word
+
+	// MintGasCost is the gas cost of a call to the native minter precompile.
+	MintGasCost uint64 = 30_000
+)
+
+// mintSignature is the 4 byte selector of mintNativeCoin(address,uint256), computed as
+// keccak256("mintNativeCoin(address,uint256)")[:4], so the precompile can be called from
+// Solidity via a normal interface.
+var mintSignature = [selectorLength]byte{0x4f, 0x5a, 0xaa, 0xba}
+
+// ContractNativeMinterConfigKey is the JSON key the native minter's genesis/upgrade config is nested
+// under.
+const ContractNativeMinterConfigKey = "contractNativeMinterConfig"
+
+// ContractNativeMinterConfig specifies the configuration of the native minter, using the same
+// AllowListConfig shape as the contract deployer allow list. Allow-listed addresses (Minter or Admin
+// role) may mint native coin; Admins may additionally modify the allow list itself.
+type ContractNativeMinterConfig struct {
+	AllowListConfig
+}
+
+// Configure initializes the native minter's address space by granting Admin to each address in
+// [AllowListAdmins].
+func (c *ContractNativeMinterConfig) Configure(state StateDB) {
+	c.AllowListConfig.Configure(state, ContractNativeMinterAddress)
+}
+
+func init() {
+	RegisterModule(StatefulPrecompileModule{
+		ConfigKey: ContractNativeMinterConfigKey,
+		Address:   ContractNativeMinterAddress,
+		NewConfig: func() StatefulPrecompileConfig { return new(ContractNativeMinterConfig) },
+		Contract:  ContractNativeMinterPrecompile,
+	})
+}
+
+// CanMint returns true if [s] indicates the permission to mint native coin.
+func (s AllowListRole) CanMint() bool {
+	switch s {
+	case Minter, Admin:
+		return true
+	default:
+		return false
+	}
+}
+
+// PackMintInput packs [address] and [amount] into the ABI-encoded byte slice expected as input to the
+// mintNativeCoin(address,uint256) selector of the native minter precompile.
+func PackMintInput(address common.Address, amount *big.Int) ([]byte, error) {
+	if amount == nil || amount.Sign() < 0 {
+		return nil, fmt.Errorf("cannot mint invalid amount: %v", amount)
+	}
+
+	input := make([]byte, mintInputLength)
+	copy(input, mintSignature[:])
+	copy(input[selectorLength+common.HashLength-common.AddressLength:selectorLength+common.HashLength], address[:])
+	amount.FillBytes(input[selectorLength+common.HashLength:])
+	return input, nil
+}
+
+// UnpackMintInput attempts to unpack [input] into the recipient address and amount to mint, verifying
+// that [input] is addressed to the mintNativeCoin selector.
+func UnpackMintInput(input []byte) (common.Address, *big.Int, error) {
+	if len(input) != mintInputLength {
+		return common.Address{}, nil, fmt.Errorf("unexpected input length for mint: %d", len(input))
+	}
+	if !bytes.Equal(input[:selectorLength], mintSignature[:]) {
+		return common.Address{}, nil, fmt.Errorf("unexpected selector for mint: %x", input[:selectorLength])
+	}
+
+	addressWord := input[selectorLength : selectorLength+common.HashLength]
+	address := common.BytesToAddress(addressWord)
+	amount := new(big.Int).SetBytes(input[selectorLength+common.HashLength:])
+	return address, amount, nil
+}
+
+// contractNativeMinterPrecompile implements StatefulPrecompiledContract and can be used as a
+// thread-safe singleton. Installed as the only contract at [ContractNativeMinterAddress], it
+// dispatches by input length to minting, or to modifying/reading the very allow list that gates
+// minting, so admins can add/remove minters after genesis through the same address.
+type contractNativeMinterPrecompile struct{}
+
+// Run dispatches [input] to mint, modify-allow-list, or read-allow-list logic by its length, then
+// verifies that [callerAddr] has the correct permissions for whichever was requested.
+func (c *contractNativeMinterPrecompile) Run(evm PrecompileAccessibleState, callerAddr common.Address, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+	switch len(input) {
+	case modifyAllowListInputLength:
+		return MinterAllowListPrecompile.Run(evm, callerAddr, addr, input, suppliedGas, readOnly)
+	case common.AddressLength:
+		return MinterReadAllowListPrecompile.Run(evm, callerAddr, addr, input, suppliedGas, readOnly)
+	}
+
+	// Note: this should never happen since the required gas should be verified before calling Run.
+	if suppliedGas < MintGasCost {
+		return nil, 0, fmt.Errorf("running contract native minter exceeds gas allowance (%d) < (%d)", MintGasCost, suppliedGas)
+	}
+
+	remainingGas = suppliedGas - MintGasCost
+	if readOnly {
+		return nil, remainingGas, fmt.Errorf("cannot mint native coin in read only")
+	}
+
+	// Verify that the caller is allow-listed to mint
+	callerStatus := GetAllowListStatus(evm.GetStateDB(), ContractNativeMinterAddress, callerAddr)
+	if !callerStatus.CanMint() {
+		log.Info("EVM received attempt to mint native coin from a non-allowed address", "callerAddr", callerAddr)
+		return nil, remainingGas, fmt.Errorf("caller %s cannot mint native coin", callerAddr)
+	}
+
+	to, amount, err := UnpackMintInput(input)
+	if err != nil {
+		log.Info("mint native coin reverted", "err", err)
+		return nil, remainingGas, fmt.Errorf("failed to unpack mint input: %w", err)
+	}
+
+	evm.GetStateDB().AddBalance(to, amount)
+
+	// Return an empty output and the remaining gas
+	return []byte{}, remainingGas, nil
+}
+
+// RequiredGas returns the amount of gas consumed by this precompile.
+func (c *contractNativeMinterPrecompile) RequiredGas(input []byte) uint64 {
+	switch len(input) {
+	case modifyAllowListInputLength:
+		return MinterAllowListPrecompile.RequiredGas(input)
+	case common.AddressLength:
+		return MinterReadAllowListPrecompile.RequiredGas(input)
+	default:
+		return MintGasCost
+	}
+}
@@ -0,0 +1,90 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package precompile
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestPackUnpackMintInput(t *testing.T) {
+	addr := common.HexToAddress("0x0123")
+	amount := big.NewInt(1_000_000)
+
+	input, err := PackMintInput(addr, amount)
+	if err != nil {
+		t.Fatalf("failed to pack mint input: %s", err)
+	}
+
+	gotAddr, gotAmount, err := UnpackMintInput(input)
+	if err != nil {
+		t.Fatalf("failed to unpack mint input: %s", err)
+	}
+	if gotAddr != addr {
+		t.Fatalf("expected address %s, got %s", addr, gotAddr)
+	}
+	if gotAmount.Cmp(amount) != 0 {
+		t.Fatalf("expected amount %s, got %s", amount, gotAmount)
+	}
+
+	if _, err := PackMintInput(addr, big.NewInt(-1)); err == nil {
+		t.Fatal("expected error packing a negative amount")
+	}
+
+	if _, _, err := UnpackMintInput(input[:len(input)-1]); err == nil {
+		t.Fatal("expected error unpacking input of the wrong length")
+	}
+}
+
+func TestMintRun(t *testing.T) {
+	caller := common.HexToAddress("0x1")
+	recipient := common.HexToAddress("0x2")
+	amount := big.NewInt(1_000_000)
+
+	tests := []struct {
+		name       string
+		callerRole AllowListRole
+		readOnly   bool
+		wantErr    bool
+	}{
+		{"minter can mint", Minter, false, false},
+		{"admin can mint", Admin, false, false},
+		{"deployer cannot mint", Deployer, false, true},
+		{"non-allow-listed caller cannot mint", None, false, true},
+		{"read-only calls are rejected", Minter, true, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			state := newMockStateDB()
+			setAllowListStatus(state, ContractNativeMinterAddress, caller, common.Hash(test.callerRole))
+			evm := &mockAccessibleState{state: state}
+
+			input, err := PackMintInput(recipient, amount)
+			if err != nil {
+				t.Fatalf("failed to pack input: %s", err)
+			}
+
+			contract := ContractNativeMinterPrecompile
+			_, _, err = contract.Run(evm, caller, ContractNativeMinterAddress, input, contract.RequiredGas(input), test.readOnly)
+			if test.wantErr {
+				if err == nil {
+					t.Fatal("expected an error, got none")
+				}
+				if got := state.GetBalance(recipient); got.Sign() != 0 {
+					t.Fatalf("expected no balance to be minted, got %s", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("expected no error, got: %s", err)
+			}
+			if got := state.GetBalance(recipient); got.Cmp(amount) != 0 {
+				t.Fatalf("expected balance %s, got %s", amount, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,320 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package precompile
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// FeeConfigManagerAddress is the address of the fee config manager precompile, which allows
+// allow-listed admins to update the chain's dynamic fee configuration at runtime.
+var FeeConfigManagerAddress = common.HexToAddress("0x0200000000000000000000000000000000000003")
+
+// Singleton StatefulPrecompiledContracts for W/R access to the fee config manager allow list.
+var (
+	FeeManagerAllowListPrecompile     StatefulPrecompiledContract = NewModifyAllowListPrecompile(FeeConfigManagerAddress)
+	FeeManagerReadAllowListPrecompile StatefulPrecompiledContract = NewReadAllowListPrecompile(FeeConfigManagerAddress)
+	FeeConfigManagerPrecompile        StatefulPrecompiledContract = &feeConfigManagerPrecompile{}
+)
+
+// feeConfigNumFields is the number of *big.Int fields making up a FeeConfig, each of which is
+// stored in its own state slot under [FeeConfigManagerAddress].
+const feeConfigNumFields = 8
+
+// State slots under [FeeConfigManagerAddress], one per FeeConfig field plus a slot recording the
+// block number at which the fee config was last changed.
+var (
+	gasLimitSlot                 = common.BigToHash(big.NewInt(0))
+	targetBlockRateSlot          = common.BigToHash(big.NewInt(1))
+	minBaseFeeSlot               = common.BigToHash(big.NewInt(2))
+	targetGasSlot                = common.BigToHash(big.NewInt(3))
+	baseFeeChangeDenominatorSlot = common.BigToHash(big.NewInt(4))
+	minBlockGasCostSlot          = common.BigToHash(big.NewInt(5))
+	maxBlockGasCostSlot          = common.BigToHash(big.NewInt(6))
+	blockGasCostStepSlot         = common.BigToHash(big.NewInt(7))
+	feeConfigLastChangedAtSlot   = common.BigToHash(big.NewInt(8))
+)
+
+const (
+	// writeGasCostPerSlot and readGasCostPerSlot price fee config updates/reads the same as a cold
+	// SSTORE/SLOAD would, since each field lives in its own state slot.
+	writeGasCostPerSlot uint64 = 20_000
+	readGasCostPerSlot  uint64 = 2_100
+
+	// SetFeeConfigGasCost is the gas cost of a call to setFeeConfig, one write per field plus one
+	// for the "last changed at" slot.
+	SetFeeConfigGasCost uint64 = writeGasCostPerSlot * (feeConfigNumFields + 1)
+	// GetFeeConfigGasCost is the gas cost of a call to getFeeConfig, one read per field.
+	GetFeeConfigGasCost uint64 = readGasCostPerSlot * feeConfigNumFields
+	// GetFeeConfigLastChangedAtGasCost is the gas cost of a call to getFeeConfigLastChangedAt.
+	GetFeeConfigLastChangedAtGasCost uint64 = readGasCostPerSlot
+
+	setFeeConfigInputLength = feeConfigNumFields * common.HashLength
+)
+
+var (
+	setFeeConfigSignature              = [selectorLength]byte{0x8f, 0x10, 0xb5, 0x86}
+	getFeeConfigSignature              = [selectorLength]byte{0x5f, 0xbb, 0xc0, 0xd2}
+	getFeeConfigLastChangedAtSignature = [selectorLength]byte{0x9e, 0x05, 0x54, 0x9a}
+)
+
+// FeeConfig specifies the dynamic fee parameters used to compute the base fee and block gas cost
+// of each block.
+type FeeConfig struct {
+	GasLimit                 *big.Int `json:"gasLimit"`
+	TargetBlockRate          *big.Int `json:"targetBlockRate"`
+	MinBaseFee               *big.Int `json:"minBaseFee"`
+	TargetGas                *big.Int `json:"targetGas"`
+	BaseFeeChangeDenominator *big.Int `json:"baseFeeChangeDenominator"`
+	MinBlockGasCost          *big.Int `json:"minBlockGasCost"`
+	MaxBlockGasCost          *big.Int `json:"maxBlockGasCost"`
+	BlockGasCostStep         *big.Int `json:"blockGasCostStep"`
+}
+
+// slots returns the state slots backing each field of [f], in the canonical on-chain order shared
+// by Configure, Get, and Set.
+func (f *FeeConfig) slots() [feeConfigNumFields]common.Hash {
+	return [feeConfigNumFields]common.Hash{
+		gasLimitSlot, targetBlockRateSlot, minBaseFeeSlot, targetGasSlot,
+		baseFeeChangeDenominatorSlot, minBlockGasCostSlot, maxBlockGasCostSlot, blockGasCostStepSlot,
+	}
+}
+
+// values returns the field values of [f] in the same order as slots().
+func (f *FeeConfig) values() [feeConfigNumFields]*big.Int {
+	return [feeConfigNumFields]*big.Int{
+		f.GasLimit, f.TargetBlockRate, f.MinBaseFee, f.TargetGas,
+		f.BaseFeeChangeDenominator, f.MinBlockGasCost, f.MaxBlockGasCost, f.BlockGasCostStep,
+	}
+}
+
+// setValue assigns the field at position [i] (in slots()/values() order) of [f] to [value].
+func (f *FeeConfig) setValue(i int, value *big.Int) {
+	switch i {
+	case 0:
+		f.GasLimit = value
+	case 1:
+		f.TargetBlockRate = value
+	case 2:
+		f.MinBaseFee = value
+	case 3:
+		f.TargetGas = value
+	case 4:
+		f.BaseFeeChangeDenominator = value
+	case 5:
+		f.MinBlockGasCost = value
+	case 6:
+		f.MaxBlockGasCost = value
+	case 7:
+		f.BlockGasCostStep = value
+	}
+}
+
+// FeeConfigManagerConfigKey is the JSON key the fee config manager's genesis/upgrade config is
+// nested under.
+const FeeConfigManagerConfigKey = "feeManagerConfig"
+
+// FeeConfigManagerConfig specifies the configuration of the fee config manager, using the same
+// AllowListConfig shape as the contract deployer allow list, plus the FeeConfig to install at genesis.
+type FeeConfigManagerConfig struct {
+	AllowListConfig
+
+	InitialFeeConfig *FeeConfig `json:"initialFeeConfig"`
+}
+
+// Configure initializes the fee config manager's address space by granting Admin to each address in
+// [AllowListAdmins] and writing [InitialFeeConfig], if present, to state.
+func (c *FeeConfigManagerConfig) Configure(state StateDB) {
+	c.AllowListConfig.Configure(state, FeeConfigManagerAddress)
+	if c.InitialFeeConfig != nil {
+		setFeeConfig(state, c.InitialFeeConfig, common.Big0)
+	}
+}
+
+func init() {
+	RegisterModule(StatefulPrecompileModule{
+		ConfigKey: FeeConfigManagerConfigKey,
+		Address:   FeeConfigManagerAddress,
+		NewConfig: func() StatefulPrecompileConfig { return new(FeeConfigManagerConfig) },
+		Contract:  FeeConfigManagerPrecompile,
+	})
+}
+
+// GetFeeConfig returns the fee config currently stored under [FeeConfigManagerAddress]. The chain
+// config reader consults this at each block to compute the effective fee config, overriding the
+// genesis default.
+func GetFeeConfig(state StateDB) *FeeConfig {
+	f := &FeeConfig{}
+	slots := f.slots()
+	for i, slot := range slots {
+		f.setValue(i, state.GetState(FeeConfigManagerAddress, slot).Big())
+	}
+	return f
+}
+
+// GetFeeConfigLastChangedAt returns the block number at which the fee config was last changed.
+func GetFeeConfigLastChangedAt(state StateDB) *big.Int {
+	return state.GetState(FeeConfigManagerAddress, feeConfigLastChangedAtSlot).Big()
+}
+
+// setFeeConfig writes each field of [f] to its slot under [FeeConfigManagerAddress] and records
+// [blockNumber] as the last-changed-at block.
+func setFeeConfig(state StateDB, f *FeeConfig, blockNumber *big.Int) {
+	slots := f.slots()
+	values := f.values()
+	for i, slot := range slots {
+		state.SetState(FeeConfigManagerAddress, slot, common.BigToHash(values[i]))
+	}
+	state.SetState(FeeConfigManagerAddress, feeConfigLastChangedAtSlot, common.BigToHash(blockNumber))
+}
+
+// PackGetFeeConfigInput packs the input to the getFeeConfig() selector of the fee config manager
+// precompile.
+func PackGetFeeConfigInput() []byte { return getFeeConfigSignature[:] }
+
+// PackGetFeeConfigLastChangedAtInput packs the input to the getFeeConfigLastChangedAt() selector of
+// the fee config manager precompile.
+func PackGetFeeConfigLastChangedAtInput() []byte { return getFeeConfigLastChangedAtSignature[:] }
+
+// PackSetFeeConfig packs [f] into the ABI-encoded byte slice expected as input to the
+// setFeeConfig(...) selector of the fee config manager precompile.
+func PackSetFeeConfig(f *FeeConfig) ([]byte, error) {
+	values := f.values()
+	for _, value := range values {
+		if value == nil {
+			return nil, fmt.Errorf("fee config has unset field")
+		}
+	}
+
+	input := make([]byte, selectorLength+setFeeConfigInputLength)
+	copy(input, setFeeConfigSignature[:])
+	for i, value := range values {
+		word := common.BigToHash(value)
+		copy(input[selectorLength+i*common.HashLength:], word[:])
+	}
+	return input, nil
+}
+
+// UnpackSetFeeConfigInput attempts to unpack [input] into a FeeConfig, verifying that [input] is
+// addressed to the setFeeConfig selector.
+func UnpackSetFeeConfigInput(input []byte) (*FeeConfig, error) {
+	if len(input) != selectorLength+setFeeConfigInputLength {
+		return nil, fmt.Errorf("unexpected input length for setFeeConfig: %d", len(input))
+	}
+
+	f := &FeeConfig{}
+	for i := 0; i < feeConfigNumFields; i++ {
+		word := common.BytesToHash(input[selectorLength+i*common.HashLength : selectorLength+(i+1)*common.HashLength])
+		f.setValue(i, word.Big())
+	}
+	return f, nil
+}
+
+// feeConfigManagerPrecompile implements StatefulPrecompiledContract and can be used as a
+// thread-safe singleton. Installed as the only contract at [FeeConfigManagerAddress], it dispatches
+// by input length to modifying/reading the allow list that gates fee config updates, or by leading
+// selector to setFeeConfig, getFeeConfig, and getFeeConfigLastChangedAt. Allow-listed admins may
+// update the chain's fee configuration; any caller may read it.
+type feeConfigManagerPrecompile struct{}
+
+// Run dispatches [input] by its length to the fee manager allow list, or by its leading 4 byte
+// selector to setFeeConfig, getFeeConfig, or getFeeConfigLastChangedAt.
+func (f *feeConfigManagerPrecompile) Run(evm PrecompileAccessibleState, callerAddr common.Address, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+	switch len(input) {
+	case modifyAllowListInputLength:
+		return FeeManagerAllowListPrecompile.Run(evm, callerAddr, addr, input, suppliedGas, readOnly)
+	case common.AddressLength:
+		return FeeManagerReadAllowListPrecompile.Run(evm, callerAddr, addr, input, suppliedGas, readOnly)
+	}
+
+	if len(input) < selectorLength {
+		cost := f.RequiredGas(input)
+		if suppliedGas < cost {
+			return nil, 0, fmt.Errorf("running fee config manager exceeds gas allowance (%d) < (%d)", cost, suppliedGas)
+		}
+		return nil, suppliedGas - cost, fmt.Errorf("invalid input length for fee config manager: %d", len(input))
+	}
+
+	var selector [selectorLength]byte
+	copy(selector[:], input[:selectorLength])
+
+	switch selector {
+	case getFeeConfigSignature:
+		if suppliedGas < GetFeeConfigGasCost {
+			return nil, 0, fmt.Errorf("running fee config manager exceeds gas allowance (%d) < (%d)", GetFeeConfigGasCost, suppliedGas)
+		}
+		remainingGas = suppliedGas - GetFeeConfigGasCost
+
+		values := GetFeeConfig(evm.GetStateDB()).values()
+		ret := make([]byte, 0, setFeeConfigInputLength)
+		for _, value := range values {
+			word := common.BigToHash(value)
+			ret = append(ret, word[:]...)
+		}
+		return ret, remainingGas, nil
+	case getFeeConfigLastChangedAtSignature:
+		if suppliedGas < GetFeeConfigLastChangedAtGasCost {
+			return nil, 0, fmt.Errorf("running fee config manager exceeds gas allowance (%d) < (%d)", GetFeeConfigLastChangedAtGasCost, suppliedGas)
+		}
+		remainingGas = suppliedGas - GetFeeConfigLastChangedAtGasCost
+
+		lastChangedAt := common.BigToHash(GetFeeConfigLastChangedAt(evm.GetStateDB()))
+		return lastChangedAt[:], remainingGas, nil
+	case setFeeConfigSignature:
+		if suppliedGas < SetFeeConfigGasCost {
+			return nil, 0, fmt.Errorf("running fee config manager exceeds gas allowance (%d) < (%d)", SetFeeConfigGasCost, suppliedGas)
+		}
+		remainingGas = suppliedGas - SetFeeConfigGasCost
+		if readOnly {
+			return nil, remainingGas, fmt.Errorf("cannot update fee config in read only")
+		}
+
+		callerStatus := GetAllowListStatus(evm.GetStateDB(), FeeConfigManagerAddress, callerAddr)
+		if !callerStatus.IsAdmin() {
+			log.Info("EVM received attempt to update fee config from a non-allowed address", "callerAddr", callerAddr)
+			return nil, remainingGas, fmt.Errorf("caller %s cannot update fee config", callerAddr)
+		}
+
+		feeConfig, err := UnpackSetFeeConfigInput(input)
+		if err != nil {
+			log.Info("set fee config reverted", "err", err)
+			return nil, remainingGas, fmt.Errorf("failed to unpack set fee config input: %w", err)
+		}
+
+		setFeeConfig(evm.GetStateDB(), feeConfig, evm.GetBlockNumber())
+		return []byte{}, remainingGas, nil
+	default:
+		cost := f.RequiredGas(input)
+		if suppliedGas < cost {
+			return nil, 0, fmt.Errorf("running fee config manager exceeds gas allowance (%d) < (%d)", cost, suppliedGas)
+		}
+		return nil, suppliedGas - cost, fmt.Errorf("unknown selector for fee config manager: %x", selector)
+	}
+}
+
+// RequiredGas returns the amount of gas consumed by this precompile.
+func (f *feeConfigManagerPrecompile) RequiredGas(input []byte) uint64 {
+	switch len(input) {
+	case modifyAllowListInputLength:
+		return FeeManagerAllowListPrecompile.RequiredGas(input)
+	case common.AddressLength:
+		return FeeManagerReadAllowListPrecompile.RequiredGas(input)
+	}
+	if len(input) < selectorLength {
+		return SetFeeConfigGasCost
+	}
+	switch {
+	case bytes.HasPrefix(input, getFeeConfigSignature[:]):
+		return GetFeeConfigGasCost
+	case bytes.HasPrefix(input, getFeeConfigLastChangedAtSignature[:]):
+		return GetFeeConfigLastChangedAtGasCost
+	default:
+		return SetFeeConfigGasCost
+	}
+}
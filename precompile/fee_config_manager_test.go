@@ -0,0 +1,125 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package precompile
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func testFeeConfig() *FeeConfig {
+	return &FeeConfig{
+		GasLimit:                 big.NewInt(8_000_000),
+		TargetBlockRate:          big.NewInt(2),
+		MinBaseFee:               big.NewInt(25_000_000_000),
+		TargetGas:                big.NewInt(15_000_000),
+		BaseFeeChangeDenominator: big.NewInt(36),
+		MinBlockGasCost:          big.NewInt(0),
+		MaxBlockGasCost:          big.NewInt(1_000_000),
+		BlockGasCostStep:         big.NewInt(200_000),
+	}
+}
+
+func TestPackUnpackSetFeeConfig(t *testing.T) {
+	f := testFeeConfig()
+
+	input, err := PackSetFeeConfig(f)
+	if err != nil {
+		t.Fatalf("failed to pack fee config: %s", err)
+	}
+
+	got, err := UnpackSetFeeConfigInput(input)
+	if err != nil {
+		t.Fatalf("failed to unpack fee config: %s", err)
+	}
+
+	gotValues, wantValues := got.values(), f.values()
+	for i := range wantValues {
+		if gotValues[i].Cmp(wantValues[i]) != 0 {
+			t.Fatalf("field %d: expected %s, got %s", i, wantValues[i], gotValues[i])
+		}
+	}
+
+	if _, err := PackSetFeeConfig(&FeeConfig{}); err == nil {
+		t.Fatal("expected error packing a fee config with unset fields")
+	}
+
+	if _, err := UnpackSetFeeConfigInput(input[:len(input)-1]); err == nil {
+		t.Fatal("expected error unpacking input of the wrong length")
+	}
+}
+
+func TestSetFeeConfigRun(t *testing.T) {
+	caller := common.HexToAddress("0x1")
+	feeConfig := testFeeConfig()
+
+	tests := []struct {
+		name       string
+		callerRole AllowListRole
+		readOnly   bool
+		wantErr    bool
+	}{
+		{"admin can update fee config", Admin, false, false},
+		{"deployer cannot update fee config", Deployer, false, true},
+		{"non-allow-listed caller cannot update fee config", None, false, true},
+		{"read-only calls are rejected", Admin, true, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			state := newMockStateDB()
+			setAllowListStatus(state, FeeConfigManagerAddress, caller, common.Hash(test.callerRole))
+			evm := &mockAccessibleState{state: state, blockNumber: big.NewInt(7)}
+
+			input, err := PackSetFeeConfig(feeConfig)
+			if err != nil {
+				t.Fatalf("failed to pack input: %s", err)
+			}
+
+			contract := FeeConfigManagerPrecompile
+			_, _, err = contract.Run(evm, caller, FeeConfigManagerAddress, input, contract.RequiredGas(input), test.readOnly)
+			if test.wantErr {
+				if err == nil {
+					t.Fatal("expected an error, got none")
+				}
+				if got := GetFeeConfigLastChangedAt(state); got.Sign() != 0 {
+					t.Fatalf("expected fee config to be untouched, got lastChangedAt %s", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("expected no error, got: %s", err)
+			}
+			if got := GetFeeConfig(state).MinBaseFee; got.Cmp(feeConfig.MinBaseFee) != 0 {
+				t.Fatalf("expected fee config to be persisted, got MinBaseFee %s", got)
+			}
+			if got := GetFeeConfigLastChangedAt(state); got.Sign() == 0 {
+				t.Fatal("expected lastChangedAt to be updated")
+			}
+		})
+	}
+}
+
+func TestGetFeeConfigRun(t *testing.T) {
+	state := newMockStateDB()
+	feeConfig := testFeeConfig()
+	setFeeConfig(state, feeConfig, big.NewInt(5))
+	evm := &mockAccessibleState{state: state}
+
+	contract := FeeConfigManagerPrecompile
+	input := PackGetFeeConfigInput()
+	ret, _, err := contract.Run(evm, common.HexToAddress("0x1"), FeeConfigManagerAddress, input, contract.RequiredGas(input), true)
+	if err != nil {
+		t.Fatalf("expected a read-only call to getFeeConfig to succeed, got: %s", err)
+	}
+
+	// ret is the FeeConfig's fields, in slots()/values() order, one 32 byte word each.
+	const minBaseFeeWordIndex = 2
+	gotMinBaseFee := common.BytesToHash(ret[minBaseFeeWordIndex*common.HashLength : (minBaseFeeWordIndex+1)*common.HashLength]).Big()
+	if gotMinBaseFee.Cmp(feeConfig.MinBaseFee) != 0 {
+		t.Fatalf("expected MinBaseFee %s, got %s", feeConfig.MinBaseFee, gotMinBaseFee)
+	}
+}
@@ -0,0 +1,149 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package precompile
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// StatefulPrecompileConfig is implemented by the genesis/upgrade configuration of every stateful
+// precompile module.
+type StatefulPrecompileConfig interface {
+	// Timestamp returns the block timestamp at which the precompile should be enabled.
+	Timestamp() *big.Int
+	// Configure initializes the precompile's address space in [state] according to this config.
+	Configure(state StateDB)
+}
+
+// StatefulPrecompileModule describes a single stateful precompile that third parties can register
+// without forking this package: the JSON key its genesis/upgrade config is nested under, the address
+// its contract is installed at, a constructor for its config type, and the contract itself.
+type StatefulPrecompileModule struct {
+	// ConfigKey is the JSON key genesis/upgrade configuration for this module is nested under.
+	ConfigKey string
+	// Address is the address [Contract] is installed at.
+	Address common.Address
+	// NewConfig returns a new, zero-valued instance of this module's config type, ready to be
+	// unmarshaled from JSON.
+	NewConfig func() StatefulPrecompileConfig
+	// Contract is the StatefulPrecompiledContract installed at [Address].
+	Contract StatefulPrecompiledContract
+}
+
+var (
+	modulesByConfigKey = make(map[string]StatefulPrecompileModule)
+	modulesByAddress   = make(map[common.Address]StatefulPrecompileModule)
+)
+
+// RegisterModule registers [module] so that genesis/upgrade configuration nested under
+// [module.ConfigKey] is routed to it and its contract is dispatched to at [module.Address]. It
+// panics on a duplicate [ConfigKey] or [Address], since that indicates a programming error at
+// package initialization.
+func RegisterModule(module StatefulPrecompileModule) {
+	if _, exists := modulesByConfigKey[module.ConfigKey]; exists {
+		panic(fmt.Sprintf("cannot register duplicate precompile config key: %s", module.ConfigKey))
+	}
+	if _, exists := modulesByAddress[module.Address]; exists {
+		panic(fmt.Sprintf("cannot register duplicate precompile address: %s", module.Address))
+	}
+
+	modulesByConfigKey[module.ConfigKey] = module
+	modulesByAddress[module.Address] = module
+}
+
+// GetPrecompileModule returns the module registered under [configKey], if any.
+func GetPrecompileModule(configKey string) (StatefulPrecompileModule, bool) {
+	module, ok := modulesByConfigKey[configKey]
+	return module, ok
+}
+
+// GetPrecompileModuleByAddress returns the module registered at [address], if any.
+func GetPrecompileModuleByAddress(address common.Address) (StatefulPrecompileModule, bool) {
+	module, ok := modulesByAddress[address]
+	return module, ok
+}
+
+// RegisteredModules returns every registered module, in no particular order.
+func RegisteredModules() []StatefulPrecompileModule {
+	result := make([]StatefulPrecompileModule, 0, len(modulesByConfigKey))
+	for _, module := range modulesByConfigKey {
+		result = append(result, module)
+	}
+	return result
+}
+
+// ParseGenesisPrecompiles unmarshals [rawPrecompiles] - the "precompiles" section of the genesis
+// JSON, a JSON object keyed by each module's ConfigKey - into one StatefulPrecompileConfig per
+// registered module present in it. It walks RegisteredModules rather than the keys of
+// [rawPrecompiles], so a key genesis carries for a module this binary doesn't know about (e.g. a
+// newer release) is silently ignored instead of failing to parse.
+func ParseGenesisPrecompiles(rawPrecompiles map[string]json.RawMessage) (map[common.Address]StatefulPrecompileConfig, error) {
+	configs := make(map[common.Address]StatefulPrecompileConfig)
+	for _, module := range RegisteredModules() {
+		raw, ok := rawPrecompiles[module.ConfigKey]
+		if !ok {
+			continue
+		}
+		config := module.NewConfig()
+		if err := json.Unmarshal(raw, config); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal genesis config for %q: %w", module.ConfigKey, err)
+		}
+		configs[module.Address] = config
+	}
+	return configs, nil
+}
+
+// PrecompileUpgrade names the ConfigKey of the precompile module a single network upgrade
+// reconfigures, alongside the raw config to unmarshal into that module's Config type.
+type PrecompileUpgrade struct {
+	ConfigKey string          `json:"configKey"`
+	Config    json.RawMessage `json:"config"`
+}
+
+// ParseUpgradePrecompiles unmarshals [upgrades] - the "precompileUpgrades" section of the upgrade
+// JSON, a list naming one module per entry - into one StatefulPrecompileConfig per entry, looking
+// each entry's ConfigKey up via GetPrecompileModule. Unlike ParseGenesisPrecompiles, an entry naming
+// an unregistered ConfigKey is an error: a scheduled upgrade that silently fails to apply is worse
+// than one that fails loudly at parse time.
+func ParseUpgradePrecompiles(upgrades []PrecompileUpgrade) (map[common.Address]StatefulPrecompileConfig, error) {
+	configs := make(map[common.Address]StatefulPrecompileConfig)
+	for _, upgrade := range upgrades {
+		module, ok := GetPrecompileModule(upgrade.ConfigKey)
+		if !ok {
+			return nil, fmt.Errorf("no registered precompile module for config key %q", upgrade.ConfigKey)
+		}
+		config := module.NewConfig()
+		if err := json.Unmarshal(upgrade.Config, config); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal upgrade config for %q: %w", upgrade.ConfigKey, err)
+		}
+		configs[module.Address] = config
+	}
+	return configs, nil
+}
+
+// ConfigurePrecompiles initializes [state] according to every config in [configs] whose Timestamp
+// is at or before [blockTimestamp]. Configuration order between modules does not matter, since each
+// precompile's address space is independent of every other's.
+func ConfigurePrecompiles(state StateDB, configs map[common.Address]StatefulPrecompileConfig, blockTimestamp *big.Int) {
+	for _, config := range configs {
+		if config.Timestamp().Cmp(blockTimestamp) <= 0 {
+			config.Configure(state)
+		}
+	}
+}
+
+// ActivePrecompileAt returns the StatefulPrecompiledContract the module registry has installed at
+// [address], if any. The EVM consults this to dispatch a call into a stateful precompile instead of
+// looking up ordinary contract code at that address.
+func ActivePrecompileAt(address common.Address) (StatefulPrecompiledContract, bool) {
+	module, ok := GetPrecompileModuleByAddress(address)
+	if !ok {
+		return nil, false
+	}
+	return module.Contract, true
+}
@@ -0,0 +1,66 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package precompile
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestParseGenesisPrecompiles(t *testing.T) {
+	raw := map[string]json.RawMessage{
+		TxAllowListConfigKey: json.RawMessage(`{"blockTimestamp": 0}`),
+		"unknownConfigKey":   json.RawMessage(`{}`),
+	}
+
+	configs, err := ParseGenesisPrecompiles(raw)
+	if err != nil {
+		t.Fatalf("failed to parse genesis precompiles: %s", err)
+	}
+
+	config, ok := configs[TxAllowListAddress]
+	if !ok {
+		t.Fatalf("expected a config for %s", TxAllowListAddress)
+	}
+	if _, ok := config.(*TxAllowListConfig); !ok {
+		t.Fatalf("expected *TxAllowListConfig, got %T", config)
+	}
+	if len(configs) != 1 {
+		t.Fatalf("expected the unknown config key to be ignored, got %d configs", len(configs))
+	}
+}
+
+func TestParseUpgradePrecompiles(t *testing.T) {
+	upgrades := []PrecompileUpgrade{
+		{ConfigKey: ContractNativeMinterConfigKey, Config: json.RawMessage(`{"blockTimestamp": 100}`)},
+	}
+
+	configs, err := ParseUpgradePrecompiles(upgrades)
+	if err != nil {
+		t.Fatalf("failed to parse upgrade precompiles: %s", err)
+	}
+	if _, ok := configs[ContractNativeMinterAddress]; !ok {
+		t.Fatalf("expected a config for %s", ContractNativeMinterAddress)
+	}
+
+	if _, err := ParseUpgradePrecompiles([]PrecompileUpgrade{{ConfigKey: "notRegistered"}}); err == nil {
+		t.Fatal("expected an error naming an unregistered config key")
+	}
+}
+
+func TestActivePrecompileAt(t *testing.T) {
+	contract, ok := ActivePrecompileAt(TxAllowListAddress)
+	if !ok {
+		t.Fatalf("expected a registered contract at %s", TxAllowListAddress)
+	}
+	if contract != TxAllowListPrecompile {
+		t.Fatal("expected the registered TxAllowListPrecompile singleton")
+	}
+
+	if _, ok := ActivePrecompileAt(common.HexToAddress("0xdead")); ok {
+		t.Fatal("expected no contract at an unregistered address")
+	}
+}
@@ -0,0 +1,74 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package precompile
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// readOnlyAllowListInputLength is the length of the ABI-encoded input to each of the read-only allow
+// list selectors below: a 4 byte selector followed by a single address word.
+const readOnlyAllowListInputLength = selectorLength + common.HashLength
+
+var (
+	isDeployerSignature = [selectorLength]byte{0x50, 0xc3, 0x58, 0xa4}
+	isAdminSignature    = [selectorLength]byte{0x24, 0xd7, 0x80, 0x6c}
+	isManagerSignature  = [selectorLength]byte{0xf3, 0xae, 0x24, 0x15}
+)
+
+// readOnlyAllowListPrecompile implements StatefulPrecompiledContract and can be used as a
+// thread-safe singleton. Unlike readAllowListPrecompile, which returns the raw 32 byte role hash, it
+// exposes isDeployer/isAdmin/isManager through Solidity ABI-style selectors, returning a boolean
+// word, so contracts can query permissions without decoding a role hash themselves. This mirrors the
+// libevm pattern of a read-only stateful precompiled contract.
+type readOnlyAllowListPrecompile struct {
+	address common.Address
+}
+
+// NewReadOnlyAllowListPrecompile returns a StatefulPrecompiledContract that exposes ABI-style
+// isDeployer/isAdmin/isManager queries over the allow list at [precompileAddr].
+func NewReadOnlyAllowListPrecompile(precompileAddr common.Address) StatefulPrecompiledContract {
+	return &readOnlyAllowListPrecompile{address: precompileAddr}
+}
+
+// Run dispatches [input] by its leading 4 byte selector to isDeployer, isAdmin, or isManager.
+func (ral *readOnlyAllowListPrecompile) Run(evm PrecompileAccessibleState, callerAddr common.Address, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+	if suppliedGas < ReadAllowListGasCost {
+		return nil, 0, fmt.Errorf("running allow list exceeds gas allowance (%d) < (%d)", ReadAllowListGasCost, suppliedGas)
+	}
+	remainingGas = suppliedGas - ReadAllowListGasCost
+
+	if len(input) != readOnlyAllowListInputLength {
+		return nil, remainingGas, fmt.Errorf("invalid input length for read only allow list: %d", len(input))
+	}
+
+	var selector [selectorLength]byte
+	copy(selector[:], input[:selectorLength])
+	queryAddr := common.BytesToAddress(input[selectorLength:])
+	status := GetAllowListStatus(evm.GetStateDB(), ral.address, queryAddr)
+
+	var result bool
+	switch selector {
+	case isDeployerSignature:
+		result = status.CanDeploy()
+	case isAdminSignature:
+		result = status.IsAdmin()
+	case isManagerSignature:
+		result = status.CanManage()
+	default:
+		return nil, remainingGas, fmt.Errorf("unknown selector for read only allow list: %x", selector)
+	}
+
+	resultWord := big.NewInt(0)
+	if result {
+		resultWord = big.NewInt(1)
+	}
+	return common.BigToHash(resultWord).Bytes(), remainingGas, nil
+}
+
+// RequiredGas returns the amount of gas consumed by this precompile.
+func (ral *readOnlyAllowListPrecompile) RequiredGas(input []byte) uint64 { return ReadAllowListGasCost }
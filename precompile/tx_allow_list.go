@@ -0,0 +1,64 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package precompile
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// TxAllowListAddress is the address of the tx allow list precompile, gating which addresses are
+// permitted to be the sender ("from") of a transaction on this chain.
+var TxAllowListAddress = common.HexToAddress("0x0200000000000000000000000000000000000002")
+
+// TxAllowListPrecompile is the single contract installed at [TxAllowListAddress], dispatching to
+// modify, read, or ABI-style read-only queries by input length so all three are reachable from the
+// one registered address.
+var TxAllowListPrecompile StatefulPrecompiledContract = NewAllowListPrecompile(TxAllowListAddress)
+
+// TxAllowListConfigKey is the JSON key the tx allow list's genesis/upgrade config is nested under.
+const TxAllowListConfigKey = "txAllowListConfig"
+
+// TxAllowListConfig specifies the configuration of the tx allow list, using the same AllowListConfig
+// shape as the contract deployer allow list.
+type TxAllowListConfig struct {
+	AllowListConfig
+}
+
+// Configure initializes the tx allow list's address space by granting Admin to each address in
+// [AllowListAdmins].
+func (c *TxAllowListConfig) Configure(state StateDB) {
+	c.AllowListConfig.Configure(state, TxAllowListAddress)
+}
+
+func init() {
+	RegisterModule(StatefulPrecompileModule{
+		ConfigKey: TxAllowListConfigKey,
+		Address:   TxAllowListAddress,
+		NewConfig: func() StatefulPrecompileConfig { return new(TxAllowListConfig) },
+		Contract:  TxAllowListPrecompile,
+	})
+}
+
+// GetTxAllowListStatus returns the role of [address] on the tx allow list.
+func GetTxAllowListStatus(state StateDB, address common.Address) AllowListRole {
+	return GetAllowListStatus(state, TxAllowListAddress, address)
+}
+
+// CanSubmit returns true iff [s] indicates the permission to submit a transaction, i.e. to be used as
+// the "from" address of a transaction.
+func (s AllowListRole) CanSubmit() bool {
+	switch s {
+	case Submitter, Admin:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsTxAllowed returns true if [from] is allowed to originate a transaction under the tx allow list
+// configured in [state]. This is checked as part of transaction validation, prior to state transition,
+// so that disallowed senders are rejected before any gas is spent.
+func IsTxAllowed(state StateDB, from common.Address) bool {
+	return GetTxAllowListStatus(state, from).CanSubmit()
+}
@@ -0,0 +1,26 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package precompile
+
+import "testing"
+
+func TestCanSubmit(t *testing.T) {
+	tests := []struct {
+		role AllowListRole
+		want bool
+	}{
+		{None, false},
+		{Deployer, false},
+		{Manager, false},
+		{Minter, false},
+		{Submitter, true},
+		{Admin, true},
+	}
+
+	for _, test := range tests {
+		if got := test.role.CanSubmit(); got != test.want {
+			t.Errorf("role %v: expected CanSubmit() = %v, got %v", test.role, test.want, got)
+		}
+	}
+}